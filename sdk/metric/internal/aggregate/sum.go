@@ -4,8 +4,12 @@
 package aggregate // import "go.opentelemetry.io/otel/sdk/metric/internal/aggregate"
 
 import (
+	"container/list"
 	"context"
+	"hash/maphash"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -13,67 +17,405 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
+// overflowAttrs is the attribute set the specification defines for the
+// single data point an aggregator reports in place of every measurement
+// that exceeded its cardinality limit.
+var overflowAttrs = attribute.NewSet(attribute.Bool("otel.metric.overflow", true))
+
+// defaultMaxTracked bounds the number of attribute sets a valueMap will hold
+// onto between collections when an inactivity timeout is configured. It
+// exists so a burst of unique attribute sets within a single collection
+// cycle cannot exhaust memory before the timeout has a chance to fire. The
+// bound is spread evenly across shards.
+const defaultMaxTracked = 2000
+
+// Option applies configuration to a sum aggregator.
+type Option func(*aggConfig)
+
+type aggConfig struct {
+	inactivityTimeout time.Duration
+	maxTracked        int
+	concurrency       int
+	instrument        string
+	onOverflow        func(instrument string)
+}
+
+// WithInactivityTimeout sets d as the duration an attribute set may go
+// without a new measurement before it is considered stale. Stale attribute
+// sets are dropped from the aggregator once a no-record data point has been
+// emitted for them, preventing unbounded growth from churning attribute
+// sets. A zero duration, the default, disables inactivity eviction.
+func WithInactivityTimeout(d time.Duration) Option {
+	return func(c *aggConfig) {
+		c.inactivityTimeout = d
+	}
+}
+
+// WithConcurrency sets the number of shards a sum aggregator's storage is
+// partitioned into. Each shard has its own mutex, exemplar reservoirs, and
+// stale set, so measurements against attribute sets that land in different
+// shards never contend with one another. A value less than 1 is treated as
+// 1. The default is runtime.GOMAXPROCS(0).
+func WithConcurrency(n int) Option {
+	return func(c *aggConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithInstrumentName names the instrument a sum aggregator is backing. It is
+// passed as-is to the f registered with WithOverflowCallback, so that a
+// single callback shared across instruments can tell which one overflowed.
+func WithInstrumentName(name string) Option {
+	return func(c *aggConfig) {
+		c.instrument = name
+	}
+}
+
+// WithOverflowCallback registers f to be called the first time a
+// measurement for this aggregator is redirected to the cardinality-limit
+// overflow bucket, so operators can wire that moment into logs or alerts.
+// f is called at most once for the lifetime of the aggregator, with the
+// instrument name set by WithInstrumentName, if any.
+func WithOverflowCallback(f func(instrument string)) Option {
+	return func(c *aggConfig) {
+		c.onOverflow = f
+	}
+}
+
+func newAggConfig(opts []Option) aggConfig {
+	cfg := aggConfig{
+		maxTracked:  defaultMaxTracked,
+		concurrency: runtime.GOMAXPROCS(0),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.concurrency < 1 {
+		cfg.concurrency = 1
+	}
+	return cfg
+}
+
 type sumValue[N int64 | float64] struct {
-	n     N
-	res   exemplar.Reservoir
-	attrs attribute.Set
+	n          N
+	res        exemplar.Reservoir
+	attrs      attribute.Set
+	lastUpdate time.Time
 }
 
-// valueMap is the storage for sums.
-type valueMap[N int64 | float64] struct {
+// shard is one partition of a valueMap's storage. Measurements are routed to
+// a single shard by a hash of their attribute set, so concurrent measure
+// calls against different shards never block on the same mutex.
+type shard[N int64 | float64] struct {
 	sync.Mutex
-	newRes func() exemplar.Reservoir
-	limit  limiter[sumValue[N]]
 	values map[attribute.Distinct]sumValue[N]
 	stale  map[attribute.Distinct]sumValue[N]
+
+	// lru and lruElem track this shard's attribute sets in
+	// least-to-most-recently-updated order so that, when an inactivity
+	// timeout is configured, a burst of unique attribute sets within a
+	// single collection cycle can be bounded by evicting the
+	// least-recently-updated entry instead of growing values without limit
+	// until the next collection sweeps stale entries out.
+	lru     *list.List
+	lruElem map[attribute.Distinct]*list.Element
+}
+
+// overflowBucket is the single, un-sharded destination for every
+// measurement any shard's cardinality limiter redirects to overflowAttrs. It
+// is kept separate from the regular shards so that overflow is always
+// reported as the one data point the specification calls for, regardless of
+// which shard a given over-limit measurement's original attribute set would
+// otherwise have landed in.
+type overflowBucket[N int64 | float64] struct {
+	sync.Mutex
+	value sumValue[N]
+	set   bool
+}
+
+// valueMap is the storage for sums. Storage is partitioned into shards keyed
+// by a hash of each measurement's attribute set so that high-throughput
+// instruments are not serialized behind a single mutex.
+type valueMap[N int64 | float64] struct {
+	newRes   func() exemplar.Reservoir
+	cfg      aggConfig
+	shards   []*shard[N]
+	overflow *overflowBucket[N]
+
+	// limit is the maximum number of distinct attribute sets, summed across
+	// every shard, this valueMap will track at once. A value <= 0 means
+	// unbounded.
+	limit int
+	// cardinality is the number of distinct attribute sets currently held
+	// across every shard's values (not stale, and not the overflow bucket).
+	// measure reserves a slot here before admitting a new attribute set into
+	// its shard, and every path that drops an attribute set out of a
+	// shard's values releases the slot it held, so the limit is enforced
+	// globally without shards needing to coordinate with one another beyond
+	// this counter.
+	cardinality atomic.Int64
+
+	// overflowCount is the number of measurements this valueMap has
+	// redirected to the overflow attribute set. It is only used to fire
+	// cfg.onOverflow once; it is not reported as a metric of its own. It is
+	// scoped to this valueMap, rather than kept in a process-wide map keyed
+	// by instrument name, so that two aggregators sharing the default,
+	// empty instrument name do not share a count, and so it needs no
+	// cleanup when this valueMap is discarded.
+	overflowCount atomic.Int64
+}
+
+func newValueMap[N int64 | float64](limit int, r func() exemplar.Reservoir, opts ...Option) *valueMap[N] {
+	cfg := newAggConfig(opts)
+
+	vm := &valueMap[N]{
+		newRes:   r,
+		cfg:      cfg,
+		shards:   make([]*shard[N], cfg.concurrency),
+		overflow: &overflowBucket[N]{},
+		limit:    limit,
+	}
+	for i := range vm.shards {
+		sh := &shard[N]{
+			values: make(map[attribute.Distinct]sumValue[N]),
+			stale:  make(map[attribute.Distinct]sumValue[N]),
+		}
+		if cfg.inactivityTimeout > 0 {
+			sh.lru = list.New()
+			sh.lruElem = make(map[attribute.Distinct]*list.Element)
+		}
+		vm.shards[i] = sh
+	}
+	return vm
+}
+
+// shardSeed is process-lifetime, not per-valueMap: shardFor only needs its
+// hash to be stable for the life of a single valueMap, and sharing one seed
+// across every sum aggregator avoids paying maphash.MakeSeed for each of
+// them.
+var shardSeed = maphash.MakeSeed()
+
+// shardFor returns the shard that owns key. Routing is a pure function of
+// key's hash, so a given attribute set always lands on the same shard for
+// the lifetime of the valueMap.
+//
+// key is hashed via its String form fed into a maphash.Hash rather than
+// with maphash.Comparable: maphash.Comparable was only added in Go 1.24,
+// newer than this module's supported minimum of Go 1.23, so it cannot be
+// used here without bumping that minimum.
+func (s *valueMap[N]) shardFor(key attribute.Distinct) *shard[N] {
+	if len(s.shards) == 1 {
+		return s.shards[0]
+	}
+	var h maphash.Hash
+	h.SetSeed(shardSeed)
+	h.WriteString(key.String())
+	return s.shards[h.Sum64()%uint64(len(s.shards))]
+}
+
+// touch records key as the most-recently-updated entry in sh and, if the
+// number of tracked attribute sets now exceeds this shard's share of
+// cfg.maxTracked, evicts the least-recently-updated one into evict.
+func (s *valueMap[N]) touch(sh *shard[N], key attribute.Distinct, evict func(attribute.Distinct)) {
+	if sh.lru == nil {
+		return
+	}
+
+	if elem, ok := sh.lruElem[key]; ok {
+		sh.lru.MoveToBack(elem)
+	} else {
+		sh.lruElem[key] = sh.lru.PushBack(key)
+	}
+
+	max := s.cfg.maxTracked / len(s.shards)
+	if max < 1 {
+		max = 1
+	}
+	for sh.lru.Len() > max {
+		front := sh.lru.Front()
+		oldest := front.Value.(attribute.Distinct)
+		sh.lru.Remove(front)
+		delete(sh.lruElem, oldest)
+		if oldest != key {
+			evict(oldest)
+		}
+	}
 }
 
-func newValueMap[N int64 | float64](limit int, r func() exemplar.Reservoir) *valueMap[N] {
-	return &valueMap[N]{
-		newRes: r,
-		limit:  newLimiter[sumValue[N]](limit),
-		values: make(map[attribute.Distinct]sumValue[N]),
-		stale:  make(map[attribute.Distinct]sumValue[N]),
+func (s *valueMap[N]) forget(sh *shard[N], key attribute.Distinct) {
+	if sh.lru == nil {
+		return
+	}
+	if elem, ok := sh.lruElem[key]; ok {
+		sh.lru.Remove(elem)
+		delete(sh.lruElem, key)
 	}
 }
 
 func (s *valueMap[N]) measure(ctx context.Context, value N, fltrAttr attribute.Set, droppedAttr []attribute.KeyValue) {
 	t := now()
 
-	s.Lock()
-	defer s.Unlock()
+	key := fltrAttr.Equivalent()
+	sh := s.shardFor(key)
+
+	sh.Lock()
+	if _, ok := sh.values[key]; !ok {
+		// key is not yet tracked anywhere: admitting it would grow
+		// cardinality by one, so reserve that slot up front against the
+		// limit shared by every shard, not just this one. If the
+		// reservation pushes cardinality over the limit, back it out and
+		// redirect this measurement to the overflow bucket instead.
+		if s.limit > 0 && s.cardinality.Add(1) > int64(s.limit) {
+			s.cardinality.Add(-1)
+			sh.Unlock()
+			s.measureOverflow(ctx, value, t, droppedAttr)
+			return
+		}
+		// key may still be sitting in stale, left there by a concurrent
+		// expireStale or remove that ran since it was last measured. Drop
+		// that entry now that key is being re-admitted to values, or the
+		// next collection would report both a real point from values and a
+		// stale no-record point for the same attribute set.
+		delete(sh.stale, key)
+	}
+	defer sh.Unlock()
 
-	attr := s.limit.Attributes(fltrAttr, s.values)
-	v, ok := s.values[attr.Equivalent()]
+	v, ok := sh.values[key]
 	if !ok {
 		v.res = s.newRes()
 	}
 
-	v.attrs = attr
+	v.attrs = fltrAttr
 	v.n += value
+	v.lastUpdate = t
 	v.res.Offer(ctx, t, exemplar.NewValue(value), droppedAttr)
 
-	s.values[attr.Equivalent()] = v
+	sh.values[key] = v
+	s.touch(sh, key, func(evictKey attribute.Distinct) {
+		if val, ok := sh.values[evictKey]; ok {
+			sh.stale[evictKey] = val
+			delete(sh.values, evictKey)
+			s.cardinality.Add(-1)
+		}
+	})
 }
 
-func (s *valueMap[N]) remove(ctx context.Context, fltrAttr attribute.Set) {
-	s.Lock()
-	defer s.Unlock()
+// measureOverflow accumulates value into the single, un-sharded overflow
+// bucket every over-limit measurement is redirected to, records the
+// self-observability overflow count for this instrument, and fires
+// cfg.onOverflow the first time that count transitions from zero to one.
+func (s *valueMap[N]) measureOverflow(ctx context.Context, value N, t time.Time, droppedAttr []attribute.KeyValue) {
+	ob := s.overflow
+	ob.Lock()
+	if !ob.set {
+		ob.value = sumValue[N]{attrs: overflowAttrs, res: s.newRes()}
+		ob.set = true
+	}
+	ob.value.n += value
+	ob.value.lastUpdate = t
+	ob.value.res.Offer(ctx, t, exemplar.NewValue(value), droppedAttr)
+	ob.Unlock()
 
+	if s.overflowCount.Add(1) == 1 && s.cfg.onOverflow != nil {
+		s.cfg.onOverflow(s.cfg.instrument)
+	}
+}
+
+// collectOverflow reports the current contents of the overflow bucket, if
+// any measurement has landed there since it was last reset, and collects
+// its exemplars into dst. Unlike drainOverflow, it does not clear the
+// bucket, since a cumulative collection reports the overflow bucket's
+// running total as-is on every cycle rather than resetting it.
+//
+// Exemplars must be collected here, before the overflow bucket's lock is
+// released, rather than by the caller afterwards: the reservoir has no
+// synchronization of its own, so collecting from it after unlocking would
+// race with a concurrent measureOverflow's Offer into that same reservoir.
+func (s *valueMap[N]) collectOverflow(dst *[]exemplar.Exemplar) (sumValue[N], bool) {
+	s.overflow.Lock()
+	defer s.overflow.Unlock()
+	val, ok := s.overflow.value, s.overflow.set
+	if ok {
+		collectExemplars(dst, val.res.Collect)
+	}
+	return val, ok
+}
+
+// drainOverflow returns the current contents of the overflow bucket, if
+// any measurement has landed there since it was last drained, and clears
+// it in the same locked section. A delta collection must read and clear
+// the bucket atomically like this, rather than as two separate locked
+// calls, or a measureOverflow landing in the gap between the read and the
+// clear would have its contribution silently discarded by the clear.
+func (s *valueMap[N]) drainOverflow() (sumValue[N], bool) {
+	s.overflow.Lock()
+	defer s.overflow.Unlock()
+	val, ok := s.overflow.value, s.overflow.set
+	s.overflow.value = sumValue[N]{}
+	s.overflow.set = false
+	return val, ok
+}
+
+func (s *valueMap[N]) remove(ctx context.Context, fltrAttr attribute.Set) {
 	key := fltrAttr.Equivalent()
+	sh := s.shardFor(key)
+
+	sh.Lock()
+	defer sh.Unlock()
+
+	if val, ok := sh.values[key]; ok {
+		sh.stale[key] = val
+		delete(sh.values, key)
+		s.forget(sh, key)
+		s.cardinality.Add(-1)
+	}
+}
+
+// expireStale moves entries of every shard's values that have not been
+// updated since before cutoff into that shard's stale set so a single
+// no-record data point is emitted for them, mirroring the existing remove
+// path used for explicitly unregistered attribute sets. Shards are locked
+// one at a time, briefly, rather than all together.
+func (s *valueMap[N]) expireStale(cutoff time.Time) {
+	if s.cfg.inactivityTimeout <= 0 {
+		return
+	}
+	for _, sh := range s.shards {
+		sh.Lock()
+		for key, val := range sh.values {
+			if val.lastUpdate.Before(cutoff) {
+				sh.stale[key] = val
+				delete(sh.values, key)
+				s.forget(sh, key)
+				s.cardinality.Add(-1)
+			}
+		}
+		sh.Unlock()
+	}
+}
 
-	if val, ok := s.values[key]; ok {
-		s.stale[key] = val
-		delete(s.values, key)
+// growDataPoints grows dst by one element and returns the grown slice along
+// with a pointer to that element. When dst has spare capacity the slot past
+// its end is reused as-is, with whatever field values it held before, so a
+// slot reused across collection cycles keeps its previous Exemplars backing
+// array instead of reallocating one; callers set every field. Otherwise a
+// zero-value element is appended.
+func growDataPoints[T any](dst []T) ([]T, *T) {
+	if len(dst) < cap(dst) {
+		dst = dst[:len(dst)+1]
+	} else {
+		var zero T
+		dst = append(dst, zero)
 	}
+	return dst, &dst[len(dst)-1]
 }
 
 // newSum returns an aggregator that summarizes a set of measurements as their
 // arithmetic sum. Each sum is scoped by attributes and the aggregation cycle
 // the measurements were made in.
-func newSum[N int64 | float64](monotonic bool, limit int, r func() exemplar.Reservoir) *sum[N] {
+func newSum[N int64 | float64](monotonic bool, limit int, r func() exemplar.Reservoir, opts ...Option) *sum[N] {
 	return &sum[N]{
-		valueMap:  newValueMap[N](limit, r),
+		valueMap:  newValueMap[N](limit, r, opts...),
 		monotonic: monotonic,
 		start:     now(),
 	}
@@ -90,93 +432,149 @@ type sum[N int64 | float64] struct {
 func (s *sum[N]) delta(dest *metricdata.Aggregation) int {
 	t := now()
 
+	s.expireStale(t.Add(-s.cfg.inactivityTimeout))
+
 	// If *dest is not a metricdata.Sum, memory reuse is missed. In that case,
 	// use the zero-value sData and hope for better alignment next cycle.
 	sData, _ := (*dest).(metricdata.Sum[N])
 	sData.Temporality = metricdata.DeltaTemporality
 	sData.IsMonotonic = s.monotonic
 
-	s.Lock()
-	defer s.Unlock()
-
-	n := len(s.values)
-	dPts := reset(sData.DataPoints, n, n)
-
-	var i int
-	for _, val := range s.values {
-		dPts[i].Attributes = val.attrs
-		dPts[i].StartTime = s.start
-		dPts[i].Time = t
-		dPts[i].Value = val.n
-		collectExemplars(&dPts[i].Exemplars, val.res.Collect)
-		i++
+	overflow, hasOverflow := s.drainOverflow()
+
+	// Count and drain each shard under the same lock acquisition: growing
+	// dPts as values are visited, rather than sizing it from a separate,
+	// unlocked pass over the shards first, so a measurement landing between
+	// the two can never make this slice too short to hold it.
+	dPts := sData.DataPoints[:0]
+	for _, sh := range s.shards {
+		sh.Lock()
+		for _, val := range sh.values {
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = val.attrs
+			pt.StartTime = s.start
+			pt.Time = t
+			pt.Value = val.n
+			pt.NoRecordedValue = false
+			collectExemplars(&pt.Exemplars, val.res.Collect)
+		}
+		for _, val := range sh.stale {
+			// A stale attribute set (put there by expireStale above or by an
+			// explicit remove) gets one no-record point reported for it here,
+			// same as the cumulative path, rather than being silently
+			// dropped by the clear below.
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = val.attrs
+			pt.StartTime = s.start
+			pt.Time = t
+			pt.Value = N(0)
+			pt.NoRecordedValue = true
+			pt.Exemplars = pt.Exemplars[:0]
+		}
+		// Do not report stale values.
+		s.cardinality.Add(-int64(len(sh.values)))
+		clear(sh.values)
+		clear(sh.stale)
+		sh.Unlock()
+	}
+	if hasOverflow {
+		var pt *metricdata.DataPoint[N]
+		dPts, pt = growDataPoints(dPts)
+		pt.Attributes = overflow.attrs
+		pt.StartTime = s.start
+		pt.Time = t
+		pt.Value = overflow.n
+		pt.NoRecordedValue = false
+		collectExemplars(&pt.Exemplars, overflow.res.Collect)
 	}
-	// Do not report stale values.
-	clear(s.values)
-	clear(s.stale)
 	// The delta collection cycle resets.
 	s.start = t
 
 	sData.DataPoints = dPts
 	*dest = sData
 
-	return n
+	return len(dPts)
 }
 
 func (s *sum[N]) cumulative(dest *metricdata.Aggregation) int {
 	t := now()
 
+	s.expireStale(t.Add(-s.cfg.inactivityTimeout))
+
 	// If *dest is not a metricdata.Sum, memory reuse is missed. In that case,
 	// use the zero-value sData and hope for better alignment next cycle.
 	sData, _ := (*dest).(metricdata.Sum[N])
 	sData.Temporality = metricdata.CumulativeTemporality
 	sData.IsMonotonic = s.monotonic
 
-	s.Lock()
-	defer s.Unlock()
-
-	n := len(s.values) + len(s.stale)
-	dPts := reset(sData.DataPoints, n, n)
-
-	var i int
-	for _, value := range s.values {
-		dPts[i].Attributes = value.attrs
-		dPts[i].StartTime = s.start
-		dPts[i].Time = t
-		dPts[i].Value = value.n
-		collectExemplars(&dPts[i].Exemplars, value.res.Collect)
-		// TODO (#3006): This will use an unbounded amount of memory if there
-		// are unbounded number of attribute sets being aggregated. Attribute
-		// sets that become "stale" need to be forgotten so this will not
-		// overload the system.
-		i++
-	}
-	for _, value := range s.stale {
-		dPts[i].Attributes = value.attrs
-		dPts[i].StartTime = s.start
-		dPts[i].Time = t
-		dPts[i].NoRecordedValue = true
-		i++
-	}
-
-	// Stale attribute sets for which a no-record marker was emitted are not
-	// reported anymore.
-	clear(s.stale)
+	var overflowExemplars []exemplar.Exemplar
+	overflow, hasOverflow := s.collectOverflow(&overflowExemplars)
+
+	// Count and collect each shard under the same lock acquisition: growing
+	// dPts as values are visited, rather than sizing it from a separate,
+	// unlocked pass over the shards first, so a measurement landing between
+	// the two can never make this slice too short to hold it.
+	dPts := sData.DataPoints[:0]
+	for _, sh := range s.shards {
+		sh.Lock()
+		for _, value := range sh.values {
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = value.attrs
+			pt.StartTime = s.start
+			pt.Time = t
+			pt.Value = value.n
+			pt.NoRecordedValue = false
+			collectExemplars(&pt.Exemplars, value.res.Collect)
+		}
+		for _, value := range sh.stale {
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = value.attrs
+			pt.StartTime = s.start
+			pt.Time = t
+			pt.Value = N(0)
+			pt.NoRecordedValue = true
+			pt.Exemplars = pt.Exemplars[:0]
+		}
+		// Stale attribute sets for which a no-record marker was emitted are
+		// not reported anymore.
+		clear(sh.stale)
+		sh.Unlock()
+	}
+	if hasOverflow {
+		// The overflow bucket is cumulative by nature: it is never cleared
+		// here, only ever grown by measureOverflow, for as long as any
+		// measurement keeps landing in it. Its exemplars were already
+		// collected above, while the overflow bucket's lock was still held.
+		var pt *metricdata.DataPoint[N]
+		dPts, pt = growDataPoints(dPts)
+		pt.Attributes = overflow.attrs
+		pt.StartTime = s.start
+		pt.Time = t
+		pt.Value = overflow.n
+		pt.NoRecordedValue = false
+		pt.Exemplars = overflowExemplars
+	}
 
 	sData.DataPoints = dPts
 	*dest = sData
 
-	return n
+	return len(dPts)
 }
 
 // newPrecomputedSum returns an aggregator that summarizes a set of
 // observatrions as their arithmetic sum. Each sum is scoped by attributes and
 // the aggregation cycle the measurements were made in.
-func newPrecomputedSum[N int64 | float64](monotonic bool, limit int, r func() exemplar.Reservoir) *precomputedSum[N] {
+func newPrecomputedSum[N int64 | float64](monotonic bool, limit int, r func() exemplar.Reservoir, opts ...Option) *precomputedSum[N] {
 	return &precomputedSum[N]{
-		valueMap:  newValueMap[N](limit, r),
+		valueMap:  newValueMap[N](limit, r, opts...),
 		monotonic: monotonic,
 		start:     now(),
+		reported:  make(map[attribute.Distinct]N),
+		starts:    make(map[attribute.Distinct]time.Time),
 	}
 }
 
@@ -187,41 +585,113 @@ type precomputedSum[N int64 | float64] struct {
 	monotonic bool
 	start     time.Time
 
+	// reported and starts are only ever read and written from the single
+	// goroutine driving collection (delta or cumulative, never both on the
+	// same aggregator), so unlike values and stale they need no sharding or
+	// locking of their own.
 	reported map[attribute.Distinct]N
+	// starts holds, per attribute set, the StartTime a series has been
+	// reporting since. It is only used by cumulative, and only diverges from
+	// start once a counter reset is detected for that series, at which point
+	// the series restarts from the collection timestamp the reset was
+	// observed at. delta reports a reset through that data point's
+	// StartTime alone.
+	starts map[attribute.Distinct]time.Time
 }
 
 func (s *precomputedSum[N]) delta(dest *metricdata.Aggregation) int {
 	t := now()
 	newReported := make(map[attribute.Distinct]N)
 
+	s.expireStale(t.Add(-s.cfg.inactivityTimeout))
+
 	// If *dest is not a metricdata.Sum, memory reuse is missed. In that case,
 	// use the zero-value sData and hope for better alignment next cycle.
 	sData, _ := (*dest).(metricdata.Sum[N])
 	sData.Temporality = metricdata.DeltaTemporality
 	sData.IsMonotonic = s.monotonic
 
-	s.Lock()
-	defer s.Unlock()
-
-	n := len(s.values)
-	dPts := reset(sData.DataPoints, n, n)
-
-	var i int
-	for key, value := range s.values {
-		delta := value.n - s.reported[key]
-
-		dPts[i].Attributes = value.attrs
-		dPts[i].StartTime = s.start
-		dPts[i].Time = t
-		dPts[i].Value = delta
-		collectExemplars(&dPts[i].Exemplars, value.res.Collect)
-
-		newReported[key] = value.n
-		i++
+	overflow, hasOverflow := s.drainOverflow()
+
+	// Count and drain each shard under the same lock acquisition: growing
+	// dPts as values are visited, rather than sizing it from a separate,
+	// unlocked pass over the shards first, so a measurement landing between
+	// the two can never make this slice too short to hold it.
+	dPts := sData.DataPoints[:0]
+	for _, sh := range s.shards {
+		sh.Lock()
+		for key, value := range sh.values {
+			start := s.start
+
+			var delta N
+			if s.monotonic && value.n < s.reported[key] {
+				// The observed value went backwards even though this is a
+				// monotonic sum: a process restart, a wrapped counter, or an
+				// observer re-initialization. Treat it as a counter reset,
+				// report the raw value as if the previous value were 0, and
+				// start a new series from this collection.
+				delta = value.n
+				start = t
+			} else {
+				delta = value.n - s.reported[key]
+			}
+
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = value.attrs
+			pt.StartTime = start
+			pt.Time = t
+			pt.Value = delta
+			pt.NoRecordedValue = false
+			collectExemplars(&pt.Exemplars, value.res.Collect)
+
+			newReported[key] = value.n
+		}
+		for _, value := range sh.stale {
+			// A stale attribute set (put there by expireStale above or by an
+			// explicit remove) gets one no-record point reported for it
+			// here, same as the cumulative path, rather than being silently
+			// dropped by the clear below.
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = value.attrs
+			pt.StartTime = s.start
+			pt.Time = t
+			pt.Value = N(0)
+			pt.NoRecordedValue = true
+			pt.Exemplars = pt.Exemplars[:0]
+		}
+		// Unused attribute sets do not report.
+		s.cardinality.Add(-int64(len(sh.values)))
+		clear(sh.values)
+		clear(sh.stale)
+		sh.Unlock()
+	}
+	if hasOverflow {
+		// Observations are supplied fresh every collection cycle, so, like
+		// the regular attribute sets above, the overflow bucket was drained
+		// (read and reset in one step, by drainOverflow above), and its
+		// delta is computed against the total last reported for it.
+		okey := overflowAttrs.Equivalent()
+		start := s.start
+		var delta N
+		if s.monotonic && overflow.n < s.reported[okey] {
+			delta = overflow.n
+			start = t
+		} else {
+			delta = overflow.n - s.reported[okey]
+		}
+		newReported[okey] = overflow.n
+
+		var pt *metricdata.DataPoint[N]
+		dPts, pt = growDataPoints(dPts)
+		pt.Attributes = overflow.attrs
+		pt.StartTime = start
+		pt.Time = t
+		pt.Value = delta
+		pt.NoRecordedValue = false
+		collectExemplars(&pt.Exemplars, overflow.res.Collect)
 	}
-	// Unused attribute sets do not report.
-	clear(s.values)
-	clear(s.stale)
 	s.reported = newReported
 	// The delta collection cycle resets.
 	s.start = t
@@ -229,48 +699,111 @@ func (s *precomputedSum[N]) delta(dest *metricdata.Aggregation) int {
 	sData.DataPoints = dPts
 	*dest = sData
 
-	return n
+	return len(dPts)
 }
 
 func (s *precomputedSum[N]) cumulative(dest *metricdata.Aggregation) int {
 	t := now()
 
+	s.expireStale(t.Add(-s.cfg.inactivityTimeout))
+
 	// If *dest is not a metricdata.Sum, memory reuse is missed. In that case,
 	// use the zero-value sData and hope for better alignment next cycle.
 	sData, _ := (*dest).(metricdata.Sum[N])
 	sData.Temporality = metricdata.CumulativeTemporality
 	sData.IsMonotonic = s.monotonic
 
-	s.Lock()
-	defer s.Unlock()
-
-	n := len(s.values) + len(s.stale)
-	dPts := reset(sData.DataPoints, n, n)
-
-	var i int
-	for _, val := range s.values {
-		dPts[i].Attributes = val.attrs
-		dPts[i].StartTime = s.start
-		dPts[i].Time = t
-		dPts[i].Value = val.n
-		collectExemplars(&dPts[i].Exemplars, val.res.Collect)
-
-		i++
+	overflow, hasOverflow := s.drainOverflow()
+
+	// Count and drain each shard under the same lock acquisition: growing
+	// dPts as values are visited, rather than sizing it from a separate,
+	// unlocked pass over the shards first, so a measurement landing between
+	// the two can never make this slice too short to hold it.
+	dPts := sData.DataPoints[:0]
+	liveKeys := make(map[attribute.Distinct]struct{}, len(s.reported))
+	for _, sh := range s.shards {
+		sh.Lock()
+		for key, val := range sh.values {
+			start, ok := s.starts[key]
+			if !ok {
+				start = s.start
+			}
+			if s.monotonic {
+				if prev, ok := s.reported[key]; ok && val.n < prev {
+					// The observed value went backwards even though this is
+					// a monotonic sum. Treat it as a counter reset and bump
+					// this series' StartTime to the collection timestamp so
+					// a downstream reader does not have to reconstruct the
+					// reset heuristically from a large negative rate.
+					start = t
+				}
+			}
+			s.starts[key] = start
+			s.reported[key] = val.n
+			liveKeys[key] = struct{}{}
+
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = val.attrs
+			pt.StartTime = start
+			pt.Time = t
+			pt.Value = val.n
+			pt.NoRecordedValue = false
+			collectExemplars(&pt.Exemplars, val.res.Collect)
+		}
+		for key, value := range sh.stale {
+			start, ok := s.starts[key]
+			if !ok {
+				start = s.start
+			}
+
+			var pt *metricdata.DataPoint[N]
+			dPts, pt = growDataPoints(dPts)
+			pt.Attributes = value.attrs
+			pt.StartTime = start
+			pt.Time = t
+			pt.Value = N(0)
+			pt.NoRecordedValue = true
+			pt.Exemplars = pt.Exemplars[:0]
+		}
+
+		// Unused attribute sets do not report.
+		s.cardinality.Add(-int64(len(sh.values)))
+		clear(sh.values)
+		clear(sh.stale)
+		sh.Unlock()
 	}
-	for _, value := range s.stale {
-		dPts[i].Attributes = value.attrs
-		dPts[i].StartTime = s.start
-		dPts[i].Time = t
-		dPts[i].NoRecordedValue = true
-		i++
+	// A key no longer tracked in any shard's values must also have its
+	// prior reported value and StartTime forgotten, whether it got there by
+	// expiring through stale or by simply no longer being observed: an
+	// observable instrument's attribute sets can stop being measured
+	// without ever going through stale if no inactivity timeout is
+	// configured, and reported/starts must not grow without bound in that
+	// case either. Re-registering the same attribute set later would
+	// otherwise compare its next value against one from a previous
+	// "generation" of that series and could misreport a counter reset.
+	for key := range s.reported {
+		if _, ok := liveKeys[key]; !ok {
+			delete(s.reported, key)
+			delete(s.starts, key)
+		}
+	}
+	if hasOverflow {
+		// Observations are supplied fresh every collection cycle, so the
+		// overflow bucket was drained (read and reset in one step, by
+		// drainOverflow above) the same as the regular attribute sets above.
+		var pt *metricdata.DataPoint[N]
+		dPts, pt = growDataPoints(dPts)
+		pt.Attributes = overflow.attrs
+		pt.StartTime = s.start
+		pt.Time = t
+		pt.Value = overflow.n
+		pt.NoRecordedValue = false
+		collectExemplars(&pt.Exemplars, overflow.res.Collect)
 	}
-
-	// Unused attribute sets do not report.
-	clear(s.values)
-	clear(s.stale)
 
 	sData.DataPoints = dPts
 	*dest = sData
 
-	return n
+	return len(dPts)
 }
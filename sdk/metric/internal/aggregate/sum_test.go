@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/internal/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func dropReservoir() exemplar.Reservoir { return exemplar.Drop() }
+
+func attrsOf(kv ...attribute.KeyValue) attribute.Set {
+	return attribute.NewSet(kv...)
+}
+
+func TestValueMapCardinalityLimitIsShared(t *testing.T) {
+	const limit = 3
+	var overflowed atomic.Int64
+	s := newSum[int64](true, limit, dropReservoir,
+		WithConcurrency(4),
+		WithInstrumentName("test"),
+		WithOverflowCallback(func(string) { overflowed.Add(1) }),
+	)
+
+	for i := 0; i < limit; i++ {
+		s.measure(context.Background(), 1, attrsOf(attribute.Int("i", i)), nil)
+	}
+	// Whichever shards the first limit attribute sets happened to land on,
+	// the (limit+1)th distinct attribute set must still overflow: the limit
+	// is shared across shards, not divided up between them.
+	s.measure(context.Background(), 1, attrsOf(attribute.Int("i", limit)), nil)
+
+	assert.Equal(t, int64(limit), s.cardinality.Load())
+	assert.Equal(t, int64(1), overflowed.Load())
+
+	var out metricdata.Aggregation
+	n := s.cumulative(&out)
+	assert.Equal(t, limit+1, n) // limit regular series plus one overflow series.
+}
+
+func TestValueMapInactivityEviction(t *testing.T) {
+	orig := now
+	defer func() { now = orig }()
+
+	base := time.Unix(0, 0)
+	now = func() time.Time { return base }
+
+	s := newSum[int64](true, 0, dropReservoir, WithInactivityTimeout(time.Minute))
+	s.measure(context.Background(), 1, attrsOf(attribute.String("k", "v")), nil)
+
+	now = func() time.Time { return base.Add(2 * time.Minute) }
+
+	var out metricdata.Aggregation
+	n := s.cumulative(&out)
+	require.Equal(t, 1, n)
+	sData, ok := out.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sData.DataPoints, 1)
+	assert.True(t, sData.DataPoints[0].NoRecordedValue)
+
+	// The now-stale series was reported once and is gone from the next
+	// collection.
+	n = s.cumulative(&out)
+	assert.Equal(t, 0, n)
+}
+
+func TestPrecomputedSumCounterReset(t *testing.T) {
+	s := newPrecomputedSum[int64](true, 0, dropReservoir)
+	key := attrsOf(attribute.String("k", "v"))
+
+	s.measure(context.Background(), 100, key, nil)
+	var out metricdata.Aggregation
+	s.cumulative(&out)
+	sData, ok := out.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sData.DataPoints, 1)
+	firstStart := sData.DataPoints[0].StartTime
+
+	// The observed cumulative value goes backwards even though this is a
+	// monotonic sum: treat it as a counter reset.
+	s.measure(context.Background(), 40, key, nil)
+	s.cumulative(&out)
+	sData, ok = out.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sData.DataPoints, 1)
+	assert.True(t, sData.DataPoints[0].StartTime.After(firstStart))
+	assert.Equal(t, int64(40), sData.DataPoints[0].Value)
+}
+
+// BenchmarkSumMeasureConcurrent demonstrates that a contended counter's
+// measure throughput scales with the number of shards its storage is
+// partitioned into, rather than being serialized behind a single mutex.
+func BenchmarkSumMeasureConcurrent(b *testing.B) {
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("shards=%d", n), func(b *testing.B) {
+			s := newSum[int64](true, 0, dropReservoir, WithConcurrency(n))
+			attrSets := make([]attribute.Set, 64)
+			for i := range attrSets {
+				attrSets[i] = attrsOf(attribute.Int("i", i))
+			}
+
+			b.SetParallelism(n)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					s.measure(context.Background(), 1, attrSets[i%len(attrSets)], nil)
+					i++
+				}
+			})
+		})
+	}
+}